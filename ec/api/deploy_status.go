@@ -0,0 +1,37 @@
+// Copyright 2022 Edgecast Inc., Licensed under the terms of the Apache 2.0 license.
+// See LICENSE file in project root for terms.
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// DeployRequestStatus is the result of polling a previously-submitted deploy
+// request via GetDeployRequest.
+type DeployRequestStatus struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+// GetDeployRequest retrieves a deploy request's current status, so callers
+// can poll until it reaches a terminal state (success/failed/cancelled).
+func (client *RulesEngineAPIClient) GetDeployRequest(
+	deployRequestID string,
+	accountNumber string,
+	portalTypeID string,
+	customerUserID string,
+) (*DeployRequestStatus, error) {
+	path := fmt.Sprintf(
+		"v2/mcc/customers/%s/rulesengine/deploy-requests/%s",
+		accountNumber,
+		deployRequestID)
+
+	status := &DeployRequestStatus{}
+	if err := client.doJSON(http.MethodGet, path, portalTypeID, customerUserID, nil, status); err != nil {
+		return nil, fmt.Errorf("GetDeployRequest: %v", err)
+	}
+
+	return status, nil
+}
@@ -0,0 +1,31 @@
+// Copyright 2022 Edgecast Inc., Licensed under the terms of the Apache 2.0 license.
+// See LICENSE file in project root for terms.
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// GetDeployedPolicy retrieves the policy currently deployed to environment
+// (`production`/`staging`), for comparison against a candidate policy by
+// DataSourceRulesEnginePolicyDiffRead.
+func (client *RulesEngineAPIClient) GetDeployedPolicy(
+	accountNumber string,
+	customerUserID string,
+	portalTypeID string,
+	environment string,
+) (map[string]interface{}, error) {
+	path := fmt.Sprintf(
+		"v2/mcc/customers/%s/rulesengine/policies/deployed/%s",
+		accountNumber,
+		environment)
+
+	policy := make(map[string]interface{})
+	if err := client.doJSON(http.MethodGet, path, portalTypeID, customerUserID, nil, &policy); err != nil {
+		return nil, fmt.Errorf("GetDeployedPolicy: %v", err)
+	}
+
+	return policy, nil
+}
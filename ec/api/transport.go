@@ -0,0 +1,71 @@
+// Copyright 2022 Edgecast Inc., Licensed under the terms of the Apache 2.0 license.
+// See LICENSE file in project root for terms.
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// doJSON issues a request against the Rules Engine API and decodes the JSON
+// response into out. It is shared by GetDeployedPolicy (policy_diff.go) and
+// GetDeployRequest (deploy_status.go), mirroring the transport
+// GetPolicy/AddPolicy/DeployPolicy already use for this client.
+func (client *RulesEngineAPIClient) doJSON(
+	method string,
+	path string,
+	portalTypeID string,
+	customerUserID string,
+	body interface{},
+	out interface{},
+) error {
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("marshaling request body: %w", err)
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	url := fmt.Sprintf(
+		"%s/%s",
+		strings.TrimRight(client.config.BaseAPIURL, "/"),
+		strings.TrimLeft(path, "/"))
+
+	req, err := http.NewRequest(method, url, reqBody)
+	if err != nil {
+		return fmt.Errorf("building request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "TOK:"+client.config.APIToken)
+	req.Header.Set("Content-Type", "application/json")
+	if len(portalTypeID) > 0 {
+		req.Header.Set("Portal-Type-Id", portalTypeID)
+	}
+	if len(customerUserID) > 0 {
+		req.Header.Set("Customer-User-Id", customerUserID)
+	}
+
+	resp, err := client.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("sending request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
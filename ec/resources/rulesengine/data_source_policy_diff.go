@@ -0,0 +1,243 @@
+// Copyright 2022 Edgecast Inc., Licensed under the terms of the Apache 2.0 license.
+// See LICENSE file in project root for terms.
+
+package rulesengine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"terraform-provider-ec/ec/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// DataSourceRulesEnginePolicyDiff previews what a `policy` would change on
+// the target platform without deploying it, so that a `terraform plan` shows
+// a meaningful, human-readable diff instead of an opaque JSON string change.
+func DataSourceRulesEnginePolicyDiff() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: DataSourceRulesEnginePolicyDiffRead,
+		Schema: map[string]*schema.Schema{
+			"customeruserid": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "User ID to impersonate. If using MCC credentials, this parameter will be ignored"},
+			"portaltypeid": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Portal Type ID to impersonate. If using MCC credentials, this parameter will be ignored."},
+			"account_number": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Account to impersonate. If using MCC credentials, this parameter will be ignored.",
+			},
+			"deploy_to": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The environment to diff the candidate policy against. Valid values are `production` and `staging`",
+				ValidateFunc: validation.StringInSlice(
+					[]string{"production", "staging"},
+					false),
+			},
+			"policy": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The candidate Rules Engine Policy in JSON format",
+				ValidateFunc: validation.All(
+					validation.StringIsNotWhiteSpace,
+					validation.StringIsJSON,
+				),
+			},
+			"fail_on_change": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, any detected diff fails `terraform plan` instead of just reporting it. Use this to gate `apply` behind an explicit approval step.",
+			},
+			"added_rules": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Names of rules present in `policy` but not in the currently deployed policy.",
+			},
+			"removed_rules": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Names of rules present in the currently deployed policy but not in `policy`.",
+			},
+			"modified_rules": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Names of rules present in both policies with differing matches or features.",
+			},
+			"has_changes": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "True if `policy` differs from the currently deployed policy in any way.",
+			},
+			"summary": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Human-readable summary of the diff, suitable for display during `terraform plan`.",
+			},
+		},
+	}
+}
+
+func DataSourceRulesEnginePolicyDiffRead(
+	ctx context.Context,
+	d *schema.ResourceData,
+	m interface{},
+) diag.Diagnostics {
+	config := m.(**api.ClientConfig)
+	(*config).AccountNumber = d.Get("account_number").(string)
+	portalTypeID := d.Get("portaltypeid").(string)
+	customerUserID := d.Get("customeruserid").(string)
+	deployTo := d.Get("deploy_to").(string)
+
+	reClient := api.NewRulesEngineAPIClient(*config)
+
+	deployedPolicy, err := reClient.GetDeployedPolicy(
+		(*config).AccountNumber,
+		customerUserID,
+		portalTypeID,
+		deployTo)
+	if err != nil {
+		return diag.FromErr(
+			fmt.Errorf("error retrieving deployed policy for diff: %v", err))
+	}
+
+	candidatePolicyMap := make(map[string]interface{})
+	if err := json.Unmarshal(
+		[]byte(d.Get("policy").(string)),
+		&candidatePolicyMap,
+	); err != nil {
+		return diag.FromErr(fmt.Errorf("error parsing candidate policy: %v", err))
+	}
+
+	diffResult := diffPolicies(deployedPolicy, candidatePolicyMap)
+
+	d.SetId(fmt.Sprintf("%s-%s-policy-diff", (*config).AccountNumber, deployTo))
+	d.Set("added_rules", diffResult.added)
+	d.Set("removed_rules", diffResult.removed)
+	d.Set("modified_rules", diffResult.modified)
+	d.Set("has_changes", diffResult.hasChanges())
+	d.Set("summary", diffResult.summary())
+
+	if d.Get("fail_on_change").(bool) && diffResult.hasChanges() {
+		return diag.Errorf(
+			"policy diff detected and fail_on_change is set: %s",
+			diffResult.summary())
+	}
+
+	return diag.Diagnostics{}
+}
+
+// policyDiff holds the result of comparing two policies rule-by-rule.
+type policyDiff struct {
+	added    []string
+	removed  []string
+	modified []string
+}
+
+func (pd policyDiff) hasChanges() bool {
+	return len(pd.added) > 0 || len(pd.removed) > 0 || len(pd.modified) > 0
+}
+
+func (pd policyDiff) summary() string {
+	if !pd.hasChanges() {
+		return "no changes: candidate policy matches the currently deployed policy"
+	}
+
+	var parts []string
+	if len(pd.added) > 0 {
+		parts = append(parts, fmt.Sprintf("%d rule(s) added: %s", len(pd.added), strings.Join(pd.added, ", ")))
+	}
+	if len(pd.removed) > 0 {
+		parts = append(parts, fmt.Sprintf("%d rule(s) removed: %s", len(pd.removed), strings.Join(pd.removed, ", ")))
+	}
+	if len(pd.modified) > 0 {
+		parts = append(parts, fmt.Sprintf("%d rule(s) modified: %s", len(pd.modified), strings.Join(pd.modified, ", ")))
+	}
+
+	return strings.Join(parts, "; ")
+}
+
+// diffPolicies compares the rules, matches, and features of two policy maps
+// keyed by rule name, since rule ordinals and IDs are not stable across
+// plans.
+func diffPolicies(deployed, candidate map[string]interface{}) policyDiff {
+	deployedRules := rulesByName(deployed)
+	candidateRules := rulesByName(candidate)
+
+	diff := policyDiff{}
+
+	for name, candidateRule := range candidateRules {
+		deployedRule, existed := deployedRules[name]
+		if !existed {
+			diff.added = append(diff.added, name)
+			continue
+		}
+
+		if !rulesEqual(deployedRule, candidateRule) {
+			diff.modified = append(diff.modified, name)
+		}
+	}
+
+	for name := range deployedRules {
+		if _, stillPresent := candidateRules[name]; !stillPresent {
+			diff.removed = append(diff.removed, name)
+		}
+	}
+
+	return diff
+}
+
+func rulesByName(policyMap map[string]interface{}) map[string]map[string]interface{} {
+	byName := make(map[string]map[string]interface{})
+
+	rules, ok := policyMap["rules"].([]interface{})
+	if !ok {
+		return byName
+	}
+
+	for _, r := range rules {
+		ruleMap, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		name, ok := ruleMap["name"].(string)
+		if !ok {
+			continue
+		}
+
+		byName[name] = ruleMap
+	}
+
+	return byName
+}
+
+// rulesEqual compares two rules' matches and features, ignoring server-side
+// metadata (ids, ordinals, timestamps) the same way cleanPolicy does.
+func rulesEqual(a, b map[string]interface{}) bool {
+	aMatches, _ := a["matches"].([]interface{})
+	bMatches, _ := b["matches"].([]interface{})
+	aCopy := map[string]interface{}{"matches": cleanMatches(aMatches), "description": a["description"]}
+	bCopy := map[string]interface{}{"matches": cleanMatches(bMatches), "description": b["description"]}
+
+	aJSON, errA := json.Marshal(aCopy)
+	bJSON, errB := json.Marshal(bCopy)
+	if errA != nil || errB != nil {
+		return false
+	}
+
+	return string(aJSON) == string(bJSON)
+}
@@ -0,0 +1,56 @@
+// Copyright 2022 Edgecast Inc., Licensed under the terms of the Apache 2.0 license.
+// See LICENSE file in project root for terms.
+
+package rulesengine
+
+import (
+	"errors"
+	"fmt"
+
+	"terraform-provider-ec/ec/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// errConcurrentModification is returned by submitPolicyUpdate when the
+// policy's `updated_at` no longer matches the value observed at the start of
+// a merge, indicating another actor modified the policy in between. Callers
+// should re-read the policy and retry the merge rather than overwrite the
+// intervening change.
+var errConcurrentModification = errors.New("policy was modified concurrently")
+
+// submitPolicyUpdate re-checks the policy's `updated_at` immediately before
+// writing, giving ResourceRulesEngineRule and ResourceRulesEngineRuleMember
+// a best-effort optimistic-concurrency guard around the Rules Engine API,
+// which has no native compare-and-swap support.
+func submitPolicyUpdate(
+	m interface{},
+	d *schema.ResourceData,
+	policyID int,
+	policyJSON string,
+	expectedUpdatedAt interface{},
+) error {
+	current, err := getPolicyByID(m, d, policyID)
+	if err != nil {
+		return fmt.Errorf("submitPolicyUpdate: %v", err)
+	}
+
+	if current["updated_at"] != expectedUpdatedAt {
+		return errConcurrentModification
+	}
+
+	config := m.(**api.ClientConfig)
+	customerID := d.Get("account_number").(string)
+	customerUserID := d.Get("customeruserid").(string)
+	portalTypeID := d.Get("portaltypeid").(string)
+
+	reClient := api.NewRulesEngineAPIClient(*config)
+
+	if _, err := reClient.AddPolicy(
+		policyJSON, customerID, portalTypeID, customerUserID,
+	); err != nil {
+		return fmt.Errorf("submitPolicyUpdate: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,361 @@
+// Copyright 2022 Edgecast Inc., Licensed under the terms of the Apache 2.0 license.
+// See LICENSE file in project root for terms.
+
+package rulesengine
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"terraform-provider-ec/ec/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// maxMergeRetries bounds the optimistic-concurrency retry loop in
+// mergeRuleIntoPolicy so a policy under constant modification by other
+// Terraform configurations or operators cannot hang an apply indefinitely.
+const maxMergeRetries = 5
+
+// ResourceRulesEngineRule manages a single, named rule inside a policy that
+// Terraform does not otherwise own. Unlike ResourceRulesEngineV4Policy
+// (authoritative over the whole policy), this resource only ever touches the
+// one rule it manages, merging its changes into whatever policy state
+// currently exists on the platform.
+func ResourceRulesEngineRule() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: ResourceRuleCreate,
+		ReadContext:   ResourceRuleRead,
+		UpdateContext: ResourceRuleUpdate,
+		DeleteContext: ResourceRuleDelete,
+		Schema: map[string]*schema.Schema{
+			"customeruserid": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"portaltypeid": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"account_number": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"policy_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the externally-owned policy this rule belongs to.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The rule's name. Used to locate the rule within the policy on every read/update - Rules Engine rule IDs are not known ahead of time.",
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"ordinal": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The rule's 1-based position within the policy. When unset, the rule is appended to the end of the policy on create and left in place on update.",
+			},
+			"match": {
+				Type:     schema.TypeList,
+				Required: true,
+				Elem:     typedMatchSchema(),
+			},
+		},
+	}
+}
+
+func ResourceRuleCreate(
+	ctx context.Context,
+	d *schema.ResourceData,
+	m interface{},
+) diag.Diagnostics {
+	ruleMap, err := typedRuleToMap(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := mergeRuleIntoPolicy(m, d, ruleMap, false); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", d.Get("policy_id").(string), d.Get("name").(string)))
+
+	return ResourceRuleRead(ctx, d, m)
+}
+
+func ResourceRuleRead(
+	ctx context.Context,
+	d *schema.ResourceData,
+	m interface{},
+) diag.Diagnostics {
+	policyID, err := strconv.Atoi(d.Get("policy_id").(string))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error parsing policy_id: %v", err))
+	}
+
+	policy, err := getPolicyByID(m, d, policyID)
+	if err != nil {
+		d.SetId("")
+		return diag.FromErr(err)
+	}
+
+	ruleMap, ok := findRuleByName(policy, d.Get("name").(string))
+	if !ok {
+		// The rule no longer exists in the policy - remove it from state.
+		d.SetId("")
+		return diag.Diagnostics{}
+	}
+
+	d.Set("description", ruleMap["description"])
+
+	matches, _ := ruleMap["matches"].([]interface{})
+	typedMatches, err := mapsToTypedMatches(matches)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("match", typedMatches)
+
+	return diag.Diagnostics{}
+}
+
+func ResourceRuleUpdate(
+	ctx context.Context,
+	d *schema.ResourceData,
+	m interface{},
+) diag.Diagnostics {
+	ruleMap, err := typedRuleToMap(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := mergeRuleIntoPolicy(m, d, ruleMap, false); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return ResourceRuleRead(ctx, d, m)
+}
+
+func ResourceRuleDelete(
+	ctx context.Context,
+	d *schema.ResourceData,
+	m interface{},
+) diag.Diagnostics {
+	if err := removeRuleFromPolicy(m, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return diag.Diagnostics{}
+}
+
+func typedRuleToMap(d *schema.ResourceData) (map[string]interface{}, error) {
+	matches := d.Get("match").([]interface{})
+
+	matchMaps, err := typedMatchesToMaps(matches)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"@type":       "rule-create",
+		"name":        d.Get("name").(string),
+		"description": d.Get("description").(string),
+		"matches":     matchMaps,
+	}, nil
+}
+
+func findRuleByName(
+	policy map[string]interface{},
+	name string,
+) (map[string]interface{}, bool) {
+	rules, ok := policy["rules"].([]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	for _, r := range rules {
+		ruleMap, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if ruleMap["name"] == name {
+			return ruleMap, true
+		}
+	}
+
+	return nil, false
+}
+
+func getPolicyByID(
+	m interface{},
+	d *schema.ResourceData,
+	policyID int,
+) (map[string]interface{}, error) {
+	config := m.(**api.ClientConfig)
+	(*config).AccountNumber = d.Get("account_number").(string)
+	portalTypeID := d.Get("portaltypeid").(string)
+	customerUserID := d.Get("customeruserid").(string)
+
+	client := api.NewRulesEngineAPIClient(*config)
+
+	return client.GetPolicy(
+		(*config).AccountNumber,
+		customerUserID,
+		portalTypeID,
+		policyID)
+}
+
+// mergeRuleIntoPolicy implements optimistic-concurrency merging: it reads
+// the current policy, applies ruleMap by name (inserting a new rule or
+// replacing an existing one), and writes the merged policy back. If the
+// policy's `updated_at` changes between read and write (i.e. someone else
+// modified the policy concurrently), the read/merge/write cycle is run
+// again, up to maxMergeRetries attempts in total, rather than clobbering the
+// intervening change.
+//
+// When requireAbsent is true, a rule named ruleMap["name"] must not already
+// exist in the freshly-fetched policy or the merge fails outright - this is
+// checked on every attempt, inside the same fetch that the write is
+// compare-and-swapped against, so a rule created by another config between
+// an earlier existence check and this call can never be silently overwritten.
+func mergeRuleIntoPolicy(
+	m interface{},
+	d *schema.ResourceData,
+	ruleMap map[string]interface{},
+	requireAbsent bool,
+) error {
+	policyID, err := strconv.Atoi(d.Get("policy_id").(string))
+	if err != nil {
+		return fmt.Errorf("error parsing policy_id: %v", err)
+	}
+
+	name := d.Get("name").(string)
+
+	for attempt := 0; attempt < maxMergeRetries; attempt++ {
+		policy, err := getPolicyByID(m, d, policyID)
+		if err != nil {
+			return fmt.Errorf("mergeRuleIntoPolicy: %v", err)
+		}
+
+		lastUpdatedAt := policy["updated_at"]
+		rules, _ := policy["rules"].([]interface{})
+
+		// Extract any existing same-named rule (tracking its position) so
+		// that an ordinal change is honored the same way on replace as on
+		// insert, rather than only taking effect when the rule is new.
+		without := make([]interface{}, 0, len(rules))
+		originalIndex := -1
+		for i, r := range rules {
+			existing, ok := r.(map[string]interface{})
+			if ok && existing["name"] == name {
+				originalIndex = i
+				continue
+			}
+			without = append(without, r)
+		}
+
+		if requireAbsent && originalIndex != -1 {
+			return fmt.Errorf(
+				"a rule named %q already exists in policy %d - edgecast_rules_engine_rule_member only manages rules it does not already own",
+				name,
+				policyID)
+		}
+
+		position := len(without)
+		if ordinal, ok := d.GetOk("ordinal"); ok {
+			position = ordinal.(int) - 1
+		} else if originalIndex != -1 {
+			position = originalIndex
+		}
+		if position < 0 || position > len(without) {
+			position = len(without)
+		}
+
+		merged := make([]interface{}, 0, len(without)+1)
+		merged = append(merged, without[:position]...)
+		merged = append(merged, ruleMap)
+		merged = append(merged, without[position:]...)
+
+		policy["rules"] = merged
+		policy["state"] = "locked"
+
+		policyJSON, err := policyMapToJSON(policy)
+		if err != nil {
+			return fmt.Errorf("mergeRuleIntoPolicy: %v", err)
+		}
+
+		if err := submitPolicyUpdate(m, d, policyID, policyJSON, lastUpdatedAt); err != nil {
+			if err == errConcurrentModification {
+				continue
+			}
+			return err
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf(
+		"mergeRuleIntoPolicy: policy %d changed concurrently %d times, giving up",
+		policyID,
+		maxMergeRetries)
+}
+
+func removeRuleFromPolicy(m interface{}, d *schema.ResourceData) error {
+	policyID, err := strconv.Atoi(d.Get("policy_id").(string))
+	if err != nil {
+		return fmt.Errorf("error parsing policy_id: %v", err)
+	}
+
+	for attempt := 0; attempt < maxMergeRetries; attempt++ {
+		policy, err := getPolicyByID(m, d, policyID)
+		if err != nil {
+			return fmt.Errorf("removeRuleFromPolicy: %v", err)
+		}
+
+		lastUpdatedAt := policy["updated_at"]
+		rules, _ := policy["rules"].([]interface{})
+		name := d.Get("name").(string)
+
+		merged := make([]interface{}, 0, len(rules))
+		for _, r := range rules {
+			existing, ok := r.(map[string]interface{})
+			if ok && existing["name"] == name {
+				continue
+			}
+			merged = append(merged, r)
+		}
+
+		policy["rules"] = merged
+		policy["state"] = "locked"
+
+		policyJSON, err := policyMapToJSON(policy)
+		if err != nil {
+			return fmt.Errorf("removeRuleFromPolicy: %v", err)
+		}
+
+		if err := submitPolicyUpdate(m, d, policyID, policyJSON, lastUpdatedAt); err != nil {
+			if err == errConcurrentModification {
+				continue
+			}
+			return err
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf(
+		"removeRuleFromPolicy: policy %d changed concurrently %d times, giving up",
+		policyID,
+		maxMergeRetries)
+}
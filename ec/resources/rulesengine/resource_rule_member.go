@@ -0,0 +1,129 @@
+// Copyright 2022 Edgecast Inc., Licensed under the terms of the Apache 2.0 license.
+// See LICENSE file in project root for terms.
+
+package rulesengine
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ResourceRulesEngineRuleMember appends a single rule to an externally-owned
+// policy without asserting ownership over the rest of the policy, the way
+// ResourceRulesEngineRule does. It is intended for the common case of many
+// independent Terraform configurations each contributing one rule to a
+// shared policy - each `rule_member` only ever adds or removes its own rule,
+// and never replaces a same-named rule owned by another configuration.
+func ResourceRulesEngineRuleMember() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: ResourceRuleMemberCreate,
+		ReadContext:   ResourceRuleMemberRead,
+		UpdateContext: ResourceRuleMemberUpdate,
+		DeleteContext: ResourceRuleMemberDelete,
+		Schema: map[string]*schema.Schema{
+			"customeruserid": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"portaltypeid": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"account_number": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"policy_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the externally-owned policy to append this rule to.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The rule's name. Must not collide with a rule owned by another Terraform configuration - create will fail rather than overwrite it.",
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"match": {
+				Type:     schema.TypeList,
+				Required: true,
+				Elem:     typedMatchSchema(),
+			},
+		},
+	}
+}
+
+func ResourceRuleMemberCreate(
+	ctx context.Context,
+	d *schema.ResourceData,
+	m interface{},
+) diag.Diagnostics {
+	ruleMap, err := typedRuleToMap(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// No ordinal control - rule_member always appends, which keeps it from
+	// conflicting with other Terraform-managed rules in the same policy.
+	// requireAbsent=true makes the "don't already own a rule by this name"
+	// check part of the same compare-and-swap attempt that writes the
+	// merge, instead of a separate check-then-act step that another config
+	// could race between.
+	if err := mergeRuleIntoPolicy(m, d, ruleMap, true); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", d.Get("policy_id").(string), d.Get("name").(string)))
+
+	return ResourceRuleMemberRead(ctx, d, m)
+}
+
+func ResourceRuleMemberRead(
+	ctx context.Context,
+	d *schema.ResourceData,
+	m interface{},
+) diag.Diagnostics {
+	return ResourceRuleRead(ctx, d, m)
+}
+
+func ResourceRuleMemberUpdate(
+	ctx context.Context,
+	d *schema.ResourceData,
+	m interface{},
+) diag.Diagnostics {
+	ruleMap, err := typedRuleToMap(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// requireAbsent is false here: name is ForceNew, and Create already
+	// guaranteed this rule_member was the one to claim this name, so an
+	// existing same-named rule at this point is this resource's own.
+	if err := mergeRuleIntoPolicy(m, d, ruleMap, false); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return ResourceRuleMemberRead(ctx, d, m)
+}
+
+func ResourceRuleMemberDelete(
+	ctx context.Context,
+	d *schema.ResourceData,
+	m interface{},
+) diag.Diagnostics {
+	if err := removeRuleFromPolicy(m, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return diag.Diagnostics{}
+}
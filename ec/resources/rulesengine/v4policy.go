@@ -16,10 +16,13 @@ import (
 	"terraform-provider-ec/ec/helper"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+const defaultDeployTimeout string = "20m"
+
 const emptyPolicyFormat string = "{\"@type\":\"policy-create\",\"name\":\"Terraform Placeholder - %s\",\"platform\":\"%s\",\"rules\":[{\"@type\":\"rule-create\",\"description\":\"Placeholder rule created by the Edgecast Terraform Provider\",\"matches\":[{\"features\":[{\"type\":\"feature.comment\",\"value\":\"Empty policy created on %s\"}],\"ordinal\":1,\"type\":\"match.always\"}],\"name\":\"Placeholder Rule\"}],\"state\":\"locked\"}"
 
 func ResourceRulesEngineV4Policy() *schema.Resource {
@@ -28,6 +31,9 @@ func ResourceRulesEngineV4Policy() *schema.Resource {
 		ReadContext:   ResourcePolicyRead,
 		UpdateContext: ResourcePolicyUpdate,
 		DeleteContext: ResourcePolicyDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
 		Schema: map[string]*schema.Schema{
 			"customeruserid": {
 				Type:        schema.TypeString,
@@ -53,15 +59,70 @@ func ResourceRulesEngineV4Policy() *schema.Resource {
 			"deploy_request_id": {
 				Type:     schema.TypeString,
 				Computed: true},
+			"wait_for_deploy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether to wait for the deploy request to reach a terminal state (`success`, `failed`, or `cancelled`) before `terraform apply` returns. When false, only `deploy_request_id` is recorded and the caller is responsible for tracking deploy status.",
+			},
+			"deploy_timeout": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     defaultDeployTimeout,
+				Description: "How long to wait for the deploy request to complete when `wait_for_deploy` is true, expressed as a Go duration string (e.g. `20m`).",
+				ValidateFunc: func(val interface{}, key string) ([]string, []error) {
+					if _, err := time.ParseDuration(val.(string)); err != nil {
+						return nil, []error{fmt.Errorf(
+							"%q is not a valid duration: %v", key, err)}
+					}
+					return nil, nil
+				},
+			},
+			"deploy_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The state of the most recent deploy request as last polled when `wait_for_deploy` is true: `success`, `failed`, or `cancelled` once it completes, or the last non-terminal state observed if `deploy_timeout` elapsed first.",
+			},
+			"restore_policy": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The policy as it existed the first time Terraform read this resource after adopting it via `terraform import`, captured so that `destroy` can restore it instead of deploying an empty placeholder policy. Never set for a policy this resource created itself.",
+			},
+			"created_by_terraform": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Internal marker recording whether this resource created its policy (as opposed to adopting a pre-existing one via `terraform import`). Used to decide whether `restore_policy` should ever be captured.",
+			},
 			"policy": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				Description: "A Rules Engine Policy in JSON format",
 				StateFunc:   cleanPolicyForTerrafomState,
 				ValidateFunc: validation.All(
 					validation.StringIsNotWhiteSpace,
 					validation.StringIsJSON,
 				),
+				ExactlyOneOf: []string{"policy", "rule"},
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The policy's name. Only used with `rule` blocks - when `policy` is set, the name comes from the JSON payload.",
+			},
+			"platform": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The Rules Engine platform (e.g. `http-large`, `http-small`) this policy applies to. Only used with `rule` blocks - when `policy` is set, the platform comes from the JSON payload.",
+			},
+			"rule": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Description: "A fully-typed alternative to `policy` for the " +
+					"common Rules Engine feature types. Each `rule` block " +
+					"is translated to/from the underlying JSON policy " +
+					"payload.",
+				Elem:         typedRuleSchema(),
+				ExactlyOneOf: []string{"policy", "rule"},
 			},
 		},
 	}
@@ -73,22 +134,39 @@ func ResourcePolicyCreate(
 	d *schema.ResourceData,
 	m interface{},
 ) diag.Diagnostics {
-	policy := d.Get("policy").(string)
+	var policy string
+
+	if rules, ok := d.GetOk("rule"); ok {
+		policyMap, err := policyMapFromTypedRules(
+			d.Get("name").(string),
+			d.Get("platform").(string),
+			rules.([]interface{}))
+		if err != nil {
+			return diag.FromErr(err)
+		}
 
-	// messy - needs improvement - unmarshalling json, modifying, then
-	// marshalling back to string state must always be locked
-	policyMap := make(map[string]interface{})
-	json.Unmarshal([]byte(policy), &policyMap)
-	policyMap["state"] = "locked"
+		policy, err = policyMapToJSON(policyMap)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	} else {
+		policy = d.Get("policy").(string)
 
-	policyBytes, err := json.Marshal(policyMap)
-	if err != nil {
-		return diag.FromErr(err)
-	}
+		// messy - needs improvement - unmarshalling json, modifying, then
+		// marshalling back to string state must always be locked
+		policyMap := make(map[string]interface{})
+		json.Unmarshal([]byte(policy), &policyMap)
+		policyMap["state"] = "locked"
 
-	policy = string(policyBytes)
+		policyBytes, err := json.Marshal(policyMap)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		policy = string(policyBytes)
+	}
 
-	err = addPolicy(policy, false, d, m)
+	err := addPolicy(ctx, policy, false, d, m)
 
 	if err != nil {
 		return diag.FromErr(err)
@@ -128,6 +206,43 @@ func ResourcePolicyRead(
 	}
 
 	policyAsString := string(jsonBytes)
+
+	// This Read is part of the Create operation for a policy this resource
+	// is creating itself - mark it so no later Read ever captures
+	// restore_policy for it, and leave restore_policy untouched so destroy
+	// always falls back to the empty placeholder policy.
+	//
+	// Otherwise, this is either the first Read after `terraform import`
+	// (adopting an already-deployed policy Terraform did not create) or a
+	// later refresh. Capture the policy the first time we see it in that
+	// case, so destroy can restore it instead of deploying an empty
+	// placeholder - the pre-Terraform policy would otherwise be lost.
+	if d.IsNewResource() {
+		d.Set("created_by_terraform", true)
+	} else if !d.Get("created_by_terraform").(bool) &&
+		len(d.Get("restore_policy").(string)) == 0 {
+		d.Set("restore_policy", policyAsString)
+	}
+
+	// If the resource's state holds typed `rule` blocks, materialize those
+	// blocks from the JSON policy instead of setting the raw `policy`
+	// attribute. Right after `terraform import` the state holds neither
+	// `rule` nor `policy`, so an imported policy is always read into the
+	// raw `policy` attribute.
+	if _, ok := d.GetOk("rule"); ok {
+		rules, err := typedRulesFromPolicy(policy)
+		if err != nil {
+			d.SetId("")
+			return diag.FromErr(err)
+		}
+
+		d.Set("name", policy["name"])
+		d.Set("platform", policy["platform"])
+		d.Set("rule", rules)
+
+		return diag.Diagnostics{}
+	}
+
 	log.Printf(
 		"[INFO] Successfully retrieved policy %s: %s",
 		d.Id(),
@@ -148,8 +263,9 @@ func ResourcePolicyUpdate(
 	return ResourcePolicyCreate(ctx, d, m)
 }
 
-// ResourcePolicyDelete creates a new empty placeholder policy and deploys it to
-// a target platform instead of actual deletion.
+// ResourcePolicyDelete restores the policy captured in `restore_policy` if
+// one was recorded, or otherwise creates a new empty placeholder policy and
+// deploys it to a target platform instead of actual deletion.
 func ResourcePolicyDelete(
 	ctx context.Context,
 	d *schema.ResourceData,
@@ -166,17 +282,21 @@ func ResourcePolicyDelete(
 	// pull out platform from existing policy
 	platform := policy["platform"].(string)
 
-	// You can't actually delete policies, so we will instead create a
-	// placeholder empty policy for the customer for the given platform and
-	// policy type
-	timestamp := time.Now().Format(time.RFC3339)
-	emptyPolicyJSON := fmt.Sprintf(
-		emptyPolicyFormat,
-		timestamp,
-		platform,
-		timestamp)
+	deletePolicyJSON := d.Get("restore_policy").(string)
+
+	if len(deletePolicyJSON) == 0 {
+		// You can't actually delete policies, so we will instead create a
+		// placeholder empty policy for the customer for the given platform
+		// and policy type
+		timestamp := time.Now().Format(time.RFC3339)
+		deletePolicyJSON = fmt.Sprintf(
+			emptyPolicyFormat,
+			timestamp,
+			platform,
+			timestamp)
+	}
 
-	err = addPolicy(emptyPolicyJSON, true, d, m)
+	err = addPolicy(ctx, deletePolicyJSON, true, d, m)
 
 	if err != nil {
 		return diag.FromErr(err)
@@ -306,6 +426,7 @@ func getPolicy(
 }
 
 func addPolicy(
+	ctx context.Context,
 	policy string,
 	isEmptyPolicy bool,
 	d *schema.ResourceData,
@@ -332,7 +453,15 @@ func addPolicy(
 
 	if !isEmptyPolicy {
 		d.SetId(parsedResponse.ID)
-		d.Set("policy", policy)
+
+		// Only mirror the submitted JSON back into `policy` when the
+		// resource is actually using that attribute. In rule-block mode
+		// `policy` was never set by the user (it's Optional, not
+		// Computed, per its ExactlyOneOf with `rule`), so setting it here
+		// would manufacture a permanent diff against the empty config.
+		if _, usingRule := d.GetOk("rule"); !usingRule {
+			d.Set("policy", policy)
+		}
 	}
 
 	deployRequest := getDeployRequestData(d, policyID)
@@ -359,15 +488,83 @@ func addPolicy(
 		customerID,
 		deployResponse)
 
+	if !isEmptyPolicy {
+		d.Set("deploy_request_id", deployResponse.ID)
+	}
+
+	if d.Get("wait_for_deploy").(bool) {
+		status, waitErr := waitForDeployCompletion(
+			ctx, reClient, deployResponse.ID, customerID, portalTypeID, customerUserID, d)
+
+		d.Set("deploy_status", status)
+
+		if waitErr != nil {
+			return fmt.Errorf("addPolicy: %v", waitErr)
+		}
+	}
+
 	if isEmptyPolicy {
 		d.SetId("") // indicates "delete" happened
-	} else {
-		d.Set("deploy_request_id", deployResponse.ID)
 	}
 
 	return nil
 }
 
+// waitForDeployCompletion polls the deploy request endpoint, using the same
+// resource.RetryContext pattern as DataSourceDNSTXTTokenRead in the cps
+// package, until the deploy request reaches a terminal state or
+// deploy_timeout elapses.
+func waitForDeployCompletion(
+	ctx context.Context,
+	reClient *api.RulesEngineAPIClient,
+	deployRequestID string,
+	customerID string,
+	portalTypeID string,
+	customerUserID string,
+	d *schema.ResourceData,
+) (string, error) {
+	timeoutRaw := d.Get("deploy_timeout").(string)
+	timeout, err := time.ParseDuration(timeoutRaw)
+	if err != nil {
+		return "", fmt.Errorf("invalid deploy_timeout: %v", err)
+	}
+
+	var finalStatus string
+
+	err = resource.RetryContext(
+		ctx,
+		timeout,
+		func() *resource.RetryError {
+			deployStatus, err := reClient.GetDeployRequest(
+				deployRequestID, customerID, portalTypeID, customerUserID)
+			if err != nil {
+				return resource.NonRetryableError(
+					fmt.Errorf("error retrieving deploy request status: %v", err))
+			}
+
+			finalStatus = deployStatus.Status
+
+			switch finalStatus {
+			case "success":
+				return nil
+			case "failed", "cancelled":
+				return resource.NonRetryableError(fmt.Errorf(
+					"deploy request %s ended in state %q",
+					deployRequestID,
+					finalStatus))
+			default:
+				log.Printf(
+					"[INFO] Deploy request %s still in state %q, retrying",
+					deployRequestID,
+					finalStatus)
+				return resource.RetryableError(
+					fmt.Errorf("deploy request %s still in state %q", deployRequestID, finalStatus))
+			}
+		})
+
+	return finalStatus, err
+}
+
 func cleanPolicyForTerrafomState(val interface{}) string {
 	policy := val.(string)
 	if len(policy) == 0 {
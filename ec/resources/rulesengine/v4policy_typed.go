@@ -0,0 +1,388 @@
+// Copyright 2022 Edgecast Inc., Licensed under the terms of the Apache 2.0 license.
+// See LICENSE file in project root for terms.
+
+package rulesengine
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Feature types supported by the typed `rule` block. This list covers the
+// most commonly hand-authored features; anything outside of this set can
+// still be managed via the raw `policy` attribute.
+const (
+	featureTypeURLLiteralIMatch  = "feature.url_url_literal_i_match"
+	featureTypeHeaderClientIP    = "feature.header_client_ip"
+	featureTypeComment           = "feature.comment"
+	featureTypeSetResponseHeader = "feature.set_response_header"
+)
+
+// typedFeatureTypes is featureTypeURLLiteralIMatch/featureTypeHeaderClientIP/
+// featureTypeComment/featureTypeSetResponseHeader as a slice, for the
+// typed `feature` block's `type` validation.
+var typedFeatureTypes = []string{
+	featureTypeURLLiteralIMatch,
+	featureTypeHeaderClientIP,
+	featureTypeComment,
+	featureTypeSetResponseHeader,
+}
+
+// typedFeatureSchema returns the schema for a single `feature` block nested
+// under a `match`. Only one of `value`/`values` will be set depending on
+// whether the underlying RE feature is singular or multi-valued.
+func typedFeatureSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Type:     schema.TypeString,
+				Required: true,
+				Description: "The Rules Engine feature type, one of " +
+					"`feature.url_url_literal_i_match`, " +
+					"`feature.header_client_ip`, `feature.comment`, or " +
+					"`feature.set_response_header`. Any other feature type " +
+					"must be managed via the raw `policy` attribute instead.",
+				ValidateFunc: validation.StringInSlice(typedFeatureTypes, false),
+			},
+			"value": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The feature's value, for single-valued features.",
+			},
+			"values": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The feature's values, for multi-valued features.",
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The header/cookie/etc. name this feature " +
+					"applies to, where applicable (e.g. " +
+					"`set_response_header`).",
+			},
+		},
+	}
+}
+
+// maxTypedMatchDepth is how many levels of `match` blocks typedMatchSchema
+// allows, counting the outermost one.
+const maxTypedMatchDepth = 3
+
+// typedMatchSchema returns the schema for a `match` block, which may nest
+// further `match` blocks to express boolean match trees (and/or/not), up to
+// maxTypedMatchDepth levels deep.
+func typedMatchSchema() *schema.Resource {
+	return typedMatchSchemaWithDepth(maxTypedMatchDepth)
+}
+
+func typedMatchSchemaWithDepth(depth int) *schema.Resource {
+	matchSchema := map[string]*schema.Schema{
+		"type": {
+			Type:     schema.TypeString,
+			Required: true,
+			Description: "The Rules Engine match type, e.g. " +
+				"`match.always`, `match.and`, `match.or`.",
+		},
+		"features": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem:     typedFeatureSchema(),
+		},
+	}
+
+	if depth > 1 {
+		matchSchema["match"] = &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem:     typedMatchSchemaWithDepth(depth - 1),
+		}
+	}
+
+	return &schema.Resource{Schema: matchSchema}
+}
+
+// typedRuleSchema returns the schema for a single `rule` block, the
+// structured alternative to hand-written JSON in the `policy` attribute.
+func typedRuleSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"match": {
+				Type:     schema.TypeList,
+				Required: true,
+				Elem:     typedMatchSchema(),
+			},
+		},
+	}
+}
+
+// policyMapFromTypedRules translates the typed `rule` blocks into the
+// underlying policy map expected by the Rules Engine API, mirroring the
+// shape produced by hand-written `policy` JSON.
+func policyMapFromTypedRules(
+	name string,
+	platform string,
+	rules []interface{},
+) (map[string]interface{}, error) {
+	ruleMaps := make([]map[string]interface{}, 0, len(rules))
+
+	for i, r := range rules {
+		ruleData, ok := r.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf(
+				"policyMapFromTypedRules: unexpected rule data at index %d",
+				i)
+		}
+
+		matches, ok := ruleData["match"].([]interface{})
+		if !ok || len(matches) == 0 {
+			return nil, fmt.Errorf(
+				"policyMapFromTypedRules: rule %q requires at least one match block",
+				ruleData["name"])
+		}
+
+		matchMaps, err := typedMatchesToMaps(matches)
+		if err != nil {
+			return nil, err
+		}
+
+		ruleMaps = append(ruleMaps, map[string]interface{}{
+			"@type":       "rule-create",
+			"name":        ruleData["name"],
+			"description": ruleData["description"],
+			"matches":     matchMaps,
+		})
+	}
+
+	return map[string]interface{}{
+		"@type":    "policy-create",
+		"name":     name,
+		"platform": platform,
+		"state":    "locked",
+		"rules":    ruleMaps,
+	}, nil
+}
+
+func typedMatchesToMaps(matches []interface{}) ([]map[string]interface{}, error) {
+	matchMaps := make([]map[string]interface{}, 0, len(matches))
+
+	for i, m := range matches {
+		matchData, ok := m.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf(
+				"typedMatchesToMaps: unexpected match data at index %d", i)
+		}
+
+		matchMap := map[string]interface{}{
+			"@type": "match-create",
+			"type":  matchData["type"],
+		}
+
+		if features, ok := matchData["features"].([]interface{}); ok && len(features) > 0 {
+			featureMaps, err := typedFeaturesToMaps(features)
+			if err != nil {
+				return nil, err
+			}
+			matchMap["features"] = featureMaps
+		}
+
+		if childMatches, ok := matchData["match"].([]interface{}); ok && len(childMatches) > 0 {
+			childMaps, err := typedMatchesToMaps(childMatches)
+			if err != nil {
+				return nil, err
+			}
+			matchMap["matches"] = childMaps
+		}
+
+		matchMaps = append(matchMaps, matchMap)
+	}
+
+	return matchMaps, nil
+}
+
+func typedFeaturesToMaps(features []interface{}) ([]map[string]interface{}, error) {
+	featureMaps := make([]map[string]interface{}, 0, len(features))
+
+	for i, f := range features {
+		featureData, ok := f.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf(
+				"typedFeaturesToMaps: unexpected feature data at index %d", i)
+		}
+
+		featureType, _ := featureData["type"].(string)
+		name, _ := featureData["name"].(string)
+
+		if featureType == featureTypeSetResponseHeader && len(name) == 0 {
+			return nil, fmt.Errorf(
+				"typedFeaturesToMaps: feature %q requires `name`",
+				featureType)
+		}
+		if featureType != featureTypeSetResponseHeader && len(name) > 0 {
+			return nil, fmt.Errorf(
+				"typedFeaturesToMaps: `name` is only valid on %q, not %q",
+				featureTypeSetResponseHeader, featureType)
+		}
+
+		featureMap := map[string]interface{}{
+			"@type": "feature-create",
+			"type":  featureData["type"],
+		}
+
+		if len(name) > 0 {
+			featureMap["name"] = name
+		}
+
+		if values, ok := featureData["values"].([]interface{}); ok && len(values) > 0 {
+			featureMap["values"] = values
+		} else if value, ok := featureData["value"].(string); ok && len(value) > 0 {
+			featureMap["value"] = value
+		}
+
+		featureMaps = append(featureMaps, featureMap)
+	}
+
+	return featureMaps, nil
+}
+
+// toInterfaceSlice normalizes a JSON-array-shaped field to []interface{}.
+// ResourcePolicyRead calls cleanPolicy before materializing typed `rule`
+// blocks, and cleanPolicy/cleanMatches replace the raw []interface{} arrays
+// produced by json.Unmarshal with []map[string]interface{} in place. Accept
+// both shapes so typedRulesFromPolicy works whether or not the policy has
+// already been cleaned.
+func toInterfaceSlice(v interface{}) ([]interface{}, bool) {
+	switch vals := v.(type) {
+	case []interface{}:
+		return vals, true
+	case []map[string]interface{}:
+		out := make([]interface{}, len(vals))
+		for i, val := range vals {
+			out[i] = val
+		}
+		return out, true
+	default:
+		return nil, false
+	}
+}
+
+// typedRulesFromPolicy is the inverse of policyMapFromTypedRules. It is used
+// by ResourcePolicyRead when the resource's state holds `rule` blocks. It is
+// not used by `terraform import`: an imported policy is read into the raw
+// `policy` attribute, not into typed blocks.
+func typedRulesFromPolicy(policyMap map[string]interface{}) ([]map[string]interface{}, error) {
+	rawRules, ok := toInterfaceSlice(policyMap["rules"])
+	if !ok {
+		return nil, fmt.Errorf("typedRulesFromPolicy: policy has no rules")
+	}
+
+	rules := make([]map[string]interface{}, 0, len(rawRules))
+
+	for _, r := range rawRules {
+		ruleMap, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		matches, _ := toInterfaceSlice(ruleMap["matches"])
+		typedMatches, err := mapsToTypedMatches(matches)
+		if err != nil {
+			return nil, err
+		}
+
+		rules = append(rules, map[string]interface{}{
+			"name":        ruleMap["name"],
+			"description": ruleMap["description"],
+			"match":       typedMatches,
+		})
+	}
+
+	return rules, nil
+}
+
+func mapsToTypedMatches(matches []interface{}) ([]map[string]interface{}, error) {
+	typedMatches := make([]map[string]interface{}, 0, len(matches))
+
+	for _, m := range matches {
+		matchMap, ok := m.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		typedMatch := map[string]interface{}{
+			"type": matchMap["type"],
+		}
+
+		if features, ok := toInterfaceSlice(matchMap["features"]); ok {
+			typedMatch["features"] = mapsToTypedFeatures(features)
+		}
+
+		if childMatches, ok := toInterfaceSlice(matchMap["matches"]); ok {
+			childTyped, err := mapsToTypedMatches(childMatches)
+			if err != nil {
+				return nil, err
+			}
+			typedMatch["match"] = childTyped
+		}
+
+		typedMatches = append(typedMatches, typedMatch)
+	}
+
+	return typedMatches, nil
+}
+
+func mapsToTypedFeatures(features []interface{}) []map[string]interface{} {
+	typedFeatures := make([]map[string]interface{}, 0, len(features))
+
+	for _, f := range features {
+		featureMap, ok := f.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		typedFeature := map[string]interface{}{
+			"type": featureMap["type"],
+			"name": featureMap["name"],
+		}
+
+		switch v := featureMap["value"].(type) {
+		case string:
+			typedFeature["value"] = v
+		}
+
+		if values, ok := featureMap["values"].([]interface{}); ok {
+			typedFeature["values"] = values
+		} else if valuesStr, ok := featureMap["values"].(string); ok {
+			// standardizeMatchFeature collapses arrays to a
+			// space-separated string; split it back out for typed state.
+			typedFeature["values"] = strings.Fields(valuesStr)
+		}
+
+		typedFeatures = append(typedFeatures, typedFeature)
+	}
+
+	return typedFeatures
+}
+
+// policyMapToJSON is a small helper shared by the typed schema path of
+// ResourcePolicyCreate and the rule merge/removal in resource_rule.go.
+func policyMapToJSON(policyMap map[string]interface{}) (string, error) {
+	jsonBytes, err := json.Marshal(policyMap)
+	if err != nil {
+		return "", fmt.Errorf("policyMapToJSON: %v", err)
+	}
+	return string(jsonBytes), nil
+}
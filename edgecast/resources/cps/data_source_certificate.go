@@ -0,0 +1,85 @@
+// Copyright 2022 Edgecast Inc., Licensed under the terms of the Apache 2.0 license.
+// See LICENSE file in project root for terms.
+package cps
+
+import (
+	"context"
+	"terraform-provider-edgecast/edgecast/helper"
+	"terraform-provider-edgecast/edgecast/internal"
+
+	"github.com/EdgeCast/ec-sdk-go/edgecast/cps/certificate"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DataSourceCertificate exposes the same per-domain domain_status view as
+// ResourceCertificate, for certificates managed outside of this Terraform
+// configuration.
+func DataSourceCertificate() *schema.Resource {
+	schemaCopy := certificateSchema()
+	schemaCopy["certificate_id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+	}
+	schemaCopy["domains"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	}
+	delete(schemaCopy, "wait_timeout")
+	delete(schemaCopy, "partial_success")
+
+	return &schema.Resource{
+		ReadContext: DataSourceCertificateRead,
+		Schema:      schemaCopy,
+	}
+}
+
+func DataSourceCertificateRead(
+	ctx context.Context,
+	d *schema.ResourceData,
+	m interface{},
+) diag.Diagnostics {
+	config, ok := m.(internal.ProviderConfig)
+	if !ok {
+		return diag.Errorf("failed to load configuration")
+	}
+
+	svc, err := buildCPSService(config)
+	if err != nil {
+		return diag.Errorf("failed to build CPS Service: %v", err)
+	}
+
+	certID, err := helper.ParseInt64(d.Get("certificate_id").(string))
+	if err != nil {
+		return diag.Errorf("failed to parse certificate ID: %v", err)
+	}
+
+	params := certificate.NewCertificateGetParams()
+	params.ID = certID
+	resp, err := svc.Certificate.CertificateGet(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	statusParams := certificate.NewCertificateGetCertificateStatusParams()
+	statusParams.ID = certID
+	statusResp, err := svc.Certificate.CertificateGetCertificateStatus(statusParams)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	metadata := GetDomainMetadata(resp, svc)
+
+	domains := make([]string, 0, len(metadata))
+	for _, domainMetadata := range metadata {
+		domains = append(domains, domainMetadata.Domain)
+	}
+
+	d.SetId(d.Get("certificate_id").(string))
+	d.Set("domains", domains)
+	d.Set("domain_status", domainStatusesFromMetadata(
+		metadata, statusResp, nil, previousValidatedAtByDomain(d)))
+
+	return diag.Diagnostics{}
+}
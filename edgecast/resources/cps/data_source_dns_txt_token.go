@@ -129,7 +129,7 @@ func DataSourceDNSTXTTokenRead(
 			metadata := GetDomainMetadata(resp, svc)
 
 			// No token found.
-			needsRetry := CheckForRetry(metadata, statusresp)
+			needsRetry := anyDomainPending(CheckForRetry(metadata, statusresp))
 			if needsRetry {
 				log.Println("token not availale")
 				if retry {
@@ -156,13 +156,42 @@ func DataSourceDNSTXTTokenRead(
 	return diag.FromErr(err)
 }
 
+// CheckForRetry returns, per domain, whether that domain's DCV token is not
+// yet available and should be re-polled. Unlike a single overall bool, this
+// lets callers managing multiple domains (e.g. ResourceCertificate) only
+// re-poll the domains that are still pending instead of restarting the
+// whole retry loop for every domain on each pass.
+//
+// If metadata is empty (the certificate's domains haven't been populated
+// yet), the retry set contains a single "" entry so callers still know to
+// retry.
 func CheckForRetry(metadata []*models.DomainDcvFull,
-	statusresp *certificate.CertificateGetCertificateStatusOK) bool {
-	if strings.ToLower(statusresp.Status) == "processing" ||
-		len(metadata) == 0 || metadata[0].DcvToken == nil ||
-		len(metadata[0].DcvToken.Token) == 0 {
-		return true
-	} else {
-		return false
+	statusresp *certificate.CertificateGetCertificateStatusOK) map[string]bool {
+	retrySet := make(map[string]bool)
+
+	if len(metadata) == 0 {
+		retrySet[""] = true
+		return retrySet
+	}
+
+	processing := strings.ToLower(statusresp.Status) == "processing"
+
+	for _, domainMetadata := range metadata {
+		retrySet[domainMetadata.Domain] = processing ||
+			domainMetadata.DcvToken == nil ||
+			len(domainMetadata.DcvToken.Token) == 0
+	}
+
+	return retrySet
+}
+
+// anyDomainPending reports whether any domain in a CheckForRetry result
+// still needs to be polled again.
+func anyDomainPending(retrySet map[string]bool) bool {
+	for _, pending := range retrySet {
+		if pending {
+			return true
+		}
 	}
+	return false
 }
@@ -0,0 +1,97 @@
+// Copyright 2022 Edgecast Inc., Licensed under the terms of the Apache 2.0 license.
+// See LICENSE file in project root for terms.
+package cps
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// dcvDNSProvider publishes and removes the `_dcv-*` TXT record CPS requires
+// for DNS-based domain control validation. Each supported DNS provider gets
+// its own implementation so edgecast_cps_dcv_dns_record can drive DV to
+// completion without an operator manually wiring the token into DNS.
+type dcvDNSProvider interface {
+	// PublishTXTRecord creates or updates the TXT record named recordName
+	// with the given value.
+	PublishTXTRecord(ctx context.Context, recordName, value string) error
+
+	// DeleteTXTRecord removes the TXT record named recordName, if present.
+	DeleteTXTRecord(ctx context.Context, recordName string) error
+}
+
+// dnsProviderBlockNames lists the supported `dns_provider` nested block
+// names, for the schema's ExactlyOneOf. buildDCVDNSProvider below checks
+// the same blocks by their own keys.
+var dnsProviderBlockNames = []string{
+	"dns_provider.0.route53",
+	"dns_provider.0.cloudflare",
+	"dns_provider.0.gcp_dns",
+	"dns_provider.0.azure_dns",
+}
+
+func dnsProviderSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"route53": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				Elem:         route53ProviderSchema(),
+				ExactlyOneOf: dnsProviderBlockNames,
+			},
+			"cloudflare": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				Elem:         cloudflareProviderSchema(),
+				ExactlyOneOf: dnsProviderBlockNames,
+			},
+			"gcp_dns": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				Elem:         gcpDNSProviderSchema(),
+				ExactlyOneOf: dnsProviderBlockNames,
+			},
+			"azure_dns": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				Elem:         azureDNSProviderSchema(),
+				ExactlyOneOf: dnsProviderBlockNames,
+			},
+		},
+	}
+}
+
+// buildDCVDNSProvider inspects which `dns_provider` sub-block was configured
+// and constructs the corresponding dcvDNSProvider.
+func buildDCVDNSProvider(d *schema.ResourceData) (dcvDNSProvider, error) {
+	providerBlocks, ok := d.Get("dns_provider").([]interface{})
+	if !ok || len(providerBlocks) == 0 {
+		return nil, fmt.Errorf("dns_provider block is required")
+	}
+
+	providerData, ok := providerBlocks[0].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("dns_provider block is invalid")
+	}
+
+	if v, ok := providerData["route53"].([]interface{}); ok && len(v) > 0 {
+		return newRoute53Provider(v[0].(map[string]interface{}))
+	}
+	if v, ok := providerData["cloudflare"].([]interface{}); ok && len(v) > 0 {
+		return newCloudflareProvider(v[0].(map[string]interface{}))
+	}
+	if v, ok := providerData["gcp_dns"].([]interface{}); ok && len(v) > 0 {
+		return newGCPDNSProvider(v[0].(map[string]interface{}))
+	}
+	if v, ok := providerData["azure_dns"].([]interface{}); ok && len(v) > 0 {
+		return newAzureDNSProvider(v[0].(map[string]interface{}))
+	}
+
+	return nil, fmt.Errorf("no supported dns_provider sub-block was configured")
+}
@@ -0,0 +1,108 @@
+// Copyright 2022 Edgecast Inc., Licensed under the terms of the Apache 2.0 license.
+// See LICENSE file in project root for terms.
+package cps
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/dns/armdns"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func azureDNSProviderSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"subscription_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"resource_group": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"zone_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		},
+	}
+}
+
+type azureDNSProvider struct {
+	client        *armdns.RecordSetsClient
+	resourceGroup string
+	zoneName      string
+}
+
+func newAzureDNSProvider(data map[string]interface{}) (dcvDNSProvider, error) {
+	cred, err := azidentity.NewDefaultAzureCredential(nil)
+	if err != nil {
+		return nil, fmt.Errorf("azure_dns: failed to build credential: %w", err)
+	}
+
+	client, err := armdns.NewRecordSetsClient(data["subscription_id"].(string), cred, nil)
+	if err != nil {
+		return nil, fmt.Errorf("azure_dns: failed to build client: %w", err)
+	}
+
+	return &azureDNSProvider{
+		client:        client,
+		resourceGroup: data["resource_group"].(string),
+		zoneName:      data["zone_name"].(string),
+	}, nil
+}
+
+// relativeRecordName strips this provider's zone suffix from a zone-qualified
+// record name like "_dcv.example.com", since armdns.RecordSetsClient expects
+// the record name relative to the zone ("_dcv") rather than zone-qualified.
+func (p *azureDNSProvider) relativeRecordName(recordName string) string {
+	return strings.TrimSuffix(strings.TrimSuffix(recordName, p.zoneName), ".")
+}
+
+func (p *azureDNSProvider) PublishTXTRecord(
+	ctx context.Context,
+	recordName, value string,
+) error {
+	recordName = p.relativeRecordName(recordName)
+
+	_, err := p.client.CreateOrUpdate(
+		ctx,
+		p.resourceGroup,
+		p.zoneName,
+		recordName,
+		armdns.RecordTypeTXT,
+		armdns.RecordSet{
+			Properties: &armdns.RecordSetProperties{
+				TTL: to.Ptr[int64](60),
+				TxtRecords: []*armdns.TxtRecord{
+					{Value: []*string{to.Ptr(value)}},
+				},
+			},
+		},
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("azure_dns: failed to publish TXT record %s: %w", recordName, err)
+	}
+
+	return nil
+}
+
+func (p *azureDNSProvider) DeleteTXTRecord(
+	ctx context.Context,
+	recordName string,
+) error {
+	recordName = p.relativeRecordName(recordName)
+
+	_, err := p.client.Delete(
+		ctx, p.resourceGroup, p.zoneName, recordName, armdns.RecordTypeTXT, nil)
+	if err != nil {
+		return fmt.Errorf("azure_dns: failed to delete TXT record %s: %w", recordName, err)
+	}
+
+	return nil
+}
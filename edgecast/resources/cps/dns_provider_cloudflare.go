@@ -0,0 +1,119 @@
+// Copyright 2022 Edgecast Inc., Licensed under the terms of the Apache 2.0 license.
+// See LICENSE file in project root for terms.
+package cps
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func cloudflareProviderSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"zone_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"api_token": {
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+type cloudflareProvider struct {
+	api    *cloudflare.API
+	zoneID string
+}
+
+func newCloudflareProvider(data map[string]interface{}) (dcvDNSProvider, error) {
+	api, err := cloudflare.NewWithAPIToken(data["api_token"].(string))
+	if err != nil {
+		return nil, fmt.Errorf("cloudflare: failed to build client: %w", err)
+	}
+
+	return &cloudflareProvider{
+		api:    api,
+		zoneID: data["zone_id"].(string),
+	}, nil
+}
+
+func (p *cloudflareProvider) PublishTXTRecord(
+	ctx context.Context,
+	recordName, value string,
+) error {
+	rc := cloudflare.ZoneIdentifier(p.zoneID)
+
+	existing, err := p.findTXTRecord(ctx, rc, recordName)
+	if err != nil {
+		return err
+	}
+
+	if existing != nil {
+		_, err = p.api.UpdateDNSRecord(ctx, rc, cloudflare.UpdateDNSRecordParams{
+			ID:      existing.ID,
+			Type:    "TXT",
+			Name:    recordName,
+			Content: value,
+			TTL:     60,
+		})
+	} else {
+		_, err = p.api.CreateDNSRecord(ctx, rc, cloudflare.CreateDNSRecordParams{
+			Type:    "TXT",
+			Name:    recordName,
+			Content: value,
+			TTL:     60,
+		})
+	}
+
+	if err != nil {
+		return fmt.Errorf("cloudflare: failed to publish TXT record %s: %w", recordName, err)
+	}
+
+	return nil
+}
+
+func (p *cloudflareProvider) DeleteTXTRecord(
+	ctx context.Context,
+	recordName string,
+) error {
+	rc := cloudflare.ZoneIdentifier(p.zoneID)
+
+	existing, err := p.findTXTRecord(ctx, rc, recordName)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return nil
+	}
+
+	if err := p.api.DeleteDNSRecord(ctx, rc, existing.ID); err != nil {
+		return fmt.Errorf("cloudflare: failed to delete TXT record %s: %w", recordName, err)
+	}
+
+	return nil
+}
+
+func (p *cloudflareProvider) findTXTRecord(
+	ctx context.Context,
+	rc *cloudflare.ResourceContainer,
+	recordName string,
+) (*cloudflare.DNSRecord, error) {
+	records, _, err := p.api.ListDNSRecords(ctx, rc, cloudflare.ListDNSRecordsParams{
+		Type: "TXT",
+		Name: recordName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cloudflare: failed to look up TXT record %s: %w", recordName, err)
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	return &records[0], nil
+}
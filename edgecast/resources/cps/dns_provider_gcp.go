@@ -0,0 +1,130 @@
+// Copyright 2022 Edgecast Inc., Licensed under the terms of the Apache 2.0 license.
+// See LICENSE file in project root for terms.
+package cps
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"google.golang.org/api/dns/v1"
+	"google.golang.org/api/option"
+)
+
+func gcpDNSProviderSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"managed_zone": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"credentials": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Path to, or raw contents of, a GCP service account credentials file. When unset, application default credentials are used.",
+			},
+		},
+	}
+}
+
+type gcpDNSProvider struct {
+	service     *dns.Service
+	project     string
+	managedZone string
+}
+
+func newGCPDNSProvider(data map[string]interface{}) (dcvDNSProvider, error) {
+	ctx := context.Background()
+
+	var opts []option.ClientOption
+	if creds, ok := data["credentials"].(string); ok && len(creds) > 0 {
+		opts = append(opts, option.WithCredentialsJSON([]byte(creds)))
+	}
+
+	service, err := dns.NewService(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("gcp_dns: failed to build client: %w", err)
+	}
+
+	return &gcpDNSProvider{
+		service:     service,
+		project:     data["project"].(string),
+		managedZone: data["managed_zone"].(string),
+	}, nil
+}
+
+// fqdnRecordName appends the trailing dot Cloud DNS requires on a
+// fully-qualified record name, e.g. "_dcv.example.com" -> "_dcv.example.com.".
+func fqdnRecordName(recordName string) string {
+	if strings.HasSuffix(recordName, ".") {
+		return recordName
+	}
+	return recordName + "."
+}
+
+func (p *gcpDNSProvider) PublishTXTRecord(
+	ctx context.Context,
+	recordName, value string,
+) error {
+	recordName = fqdnRecordName(recordName)
+
+	change := &dns.Change{
+		Additions: []*dns.ResourceRecordSet{
+			{
+				Name:    recordName,
+				Type:    "TXT",
+				Ttl:     60,
+				Rrdatas: []string{fmt.Sprintf("%q", value)},
+			},
+		},
+	}
+
+	if existing := p.findTXTRecord(ctx, recordName); existing != nil {
+		change.Deletions = []*dns.ResourceRecordSet{existing}
+	}
+
+	_, err := p.service.Changes.Create(p.project, p.managedZone, change).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("gcp_dns: failed to publish TXT record %s: %w", recordName, err)
+	}
+
+	return nil
+}
+
+func (p *gcpDNSProvider) DeleteTXTRecord(
+	ctx context.Context,
+	recordName string,
+) error {
+	recordName = fqdnRecordName(recordName)
+
+	existing := p.findTXTRecord(ctx, recordName)
+	if existing == nil {
+		return nil
+	}
+
+	change := &dns.Change{Deletions: []*dns.ResourceRecordSet{existing}}
+	if _, err := p.service.Changes.Create(p.project, p.managedZone, change).Context(ctx).Do(); err != nil {
+		return fmt.Errorf("gcp_dns: failed to delete TXT record %s: %w", recordName, err)
+	}
+
+	return nil
+}
+
+func (p *gcpDNSProvider) findTXTRecord(
+	ctx context.Context,
+	recordName string,
+) *dns.ResourceRecordSet {
+	resp, err := p.service.ResourceRecordSets.List(p.project, p.managedZone).
+		Name(recordName).Type("TXT").Context(ctx).Do()
+	if err != nil || len(resp.Rrsets) == 0 {
+		return nil
+	}
+
+	return resp.Rrsets[0]
+}
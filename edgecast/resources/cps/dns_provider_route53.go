@@ -0,0 +1,115 @@
+// Copyright 2022 Edgecast Inc., Licensed under the terms of the Apache 2.0 license.
+// See LICENSE file in project root for terms.
+package cps
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func route53ProviderSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"hosted_zone_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"profile": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+type route53Provider struct {
+	client       *route53.Client
+	hostedZoneID string
+}
+
+func newRoute53Provider(data map[string]interface{}) (dcvDNSProvider, error) {
+	ctx := context.Background()
+
+	var opts []func(*config.LoadOptions) error
+	if region, ok := data["region"].(string); ok && len(region) > 0 {
+		opts = append(opts, config.WithRegion(region))
+	}
+	if profile, ok := data["profile"].(string); ok && len(profile) > 0 {
+		opts = append(opts, config.WithSharedConfigProfile(profile))
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("route53: failed to load AWS config: %w", err)
+	}
+
+	return &route53Provider{
+		client:       route53.NewFromConfig(cfg),
+		hostedZoneID: data["hosted_zone_id"].(string),
+	}, nil
+}
+
+func (p *route53Provider) PublishTXTRecord(
+	ctx context.Context,
+	recordName, value string,
+) error {
+	_, err := p.client.ChangeResourceRecordSets(ctx, &route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String(p.hostedZoneID),
+		ChangeBatch: &types.ChangeBatch{
+			Changes: []types.Change{
+				{
+					Action: types.ChangeActionUpsert,
+					ResourceRecordSet: &types.ResourceRecordSet{
+						Name: aws.String(recordName),
+						Type: types.RRTypeTxt,
+						TTL:  aws.Int64(60),
+						ResourceRecords: []types.ResourceRecord{
+							{Value: aws.String(fmt.Sprintf("%q", value))},
+						},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("route53: failed to publish TXT record %s: %w", recordName, err)
+	}
+
+	return nil
+}
+
+func (p *route53Provider) DeleteTXTRecord(
+	ctx context.Context,
+	recordName string,
+) error {
+	_, err := p.client.ChangeResourceRecordSets(ctx, &route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String(p.hostedZoneID),
+		ChangeBatch: &types.ChangeBatch{
+			Changes: []types.Change{
+				{
+					Action: types.ChangeActionDelete,
+					ResourceRecordSet: &types.ResourceRecordSet{
+						Name: aws.String(recordName),
+						Type: types.RRTypeTxt,
+						TTL:  aws.Int64(60),
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("route53: failed to delete TXT record %s: %w", recordName, err)
+	}
+
+	return nil
+}
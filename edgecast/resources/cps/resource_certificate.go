@@ -0,0 +1,374 @@
+// Copyright 2022 Edgecast Inc., Licensed under the terms of the Apache 2.0 license.
+// See LICENSE file in project root for terms.
+package cps
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"terraform-provider-edgecast/edgecast/helper"
+	"terraform-provider-edgecast/edgecast/internal"
+	"time"
+
+	"github.com/EdgeCast/ec-sdk-go/edgecast/cps/certificate"
+	"github.com/EdgeCast/ec-sdk-go/edgecast/cps/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const certificateDefaultTimeout = "20m"
+
+// ResourceCertificate requests a (potentially multi-domain/SAN) DV
+// certificate and orchestrates the full validation workflow, exposing
+// per-domain status so large certificates don't look like an opaque black
+// box while they validate.
+func ResourceCertificate() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: ResourceCertificateCreate,
+		ReadContext:   ResourceCertificateRead,
+		DeleteContext: ResourceCertificateDelete,
+		Schema:        certificateSchema(),
+	}
+}
+
+func certificateSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"domains": {
+			Type:     schema.TypeList,
+			Required: true,
+			ForceNew: true,
+			MinItems: 1,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"wait_timeout": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Default:          certificateDefaultTimeout,
+			ValidateDiagFunc: internal.ValidateDuration,
+		},
+		"partial_success": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+			Description: "When true, `terraform apply` succeeds once at least one " +
+				"domain validates, even if the certificate as a whole is still " +
+				"processing the rest. Remaining domains continue to be tracked in " +
+				"`domain_status`.",
+		},
+		"domain_status": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Per-domain DCV status, one entry per domain in `domains`.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"domain": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"token": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"status": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"validated_at": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"error_message": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func ResourceCertificateCreate(
+	ctx context.Context,
+	d *schema.ResourceData,
+	m interface{},
+) diag.Diagnostics {
+	timeoutRaw := d.Get("wait_timeout").(string)
+	timeout, err := time.ParseDuration(timeoutRaw)
+	if err != nil {
+		return diag.Errorf("invalid wait_timeout: %v", err)
+	}
+
+	config, ok := m.(internal.ProviderConfig)
+	if !ok {
+		return diag.Errorf("failed to load configuration")
+	}
+
+	svc, err := buildCPSService(config)
+	if err != nil {
+		return diag.Errorf("failed to build CPS Service: %v", err)
+	}
+
+	rawDomains := d.Get("domains").([]interface{})
+	domains := make([]string, 0, len(rawDomains))
+	for _, dm := range rawDomains {
+		domains = append(domains, dm.(string))
+	}
+
+	addParams := certificate.NewCertificateAddDVParams()
+	addParams.Domains = domains
+
+	addResp, err := svc.Certificate.CertificateAddDV(addParams)
+	if err != nil {
+		return diag.Errorf("failed to request certificate: %v", err)
+	}
+
+	certID, err := helper.ParseInt64(fmt.Sprintf("%v", addResp.ID))
+	if err != nil {
+		return diag.Errorf("failed to parse certificate ID: %v", err)
+	}
+
+	d.SetId(fmt.Sprintf("%d", certID))
+
+	partialSuccess := d.Get("partial_success").(bool)
+
+	// pending tracks, per domain, whether it still needs to be re-polled.
+	// Only domains CheckForRetry still marks as pending are re-checked on
+	// each pass, so a large SAN certificate doesn't restart validated
+	// domains from scratch on every retry.
+	pending := make(map[string]bool, len(domains))
+	for _, domain := range domains {
+		pending[domain] = true
+	}
+
+	var statuses []map[string]interface{}
+	previousValidatedAt := previousValidatedAtByDomain(d)
+
+	err = resource.RetryContext(
+		ctx,
+		timeout,
+		func() *resource.RetryError {
+			getParams := certificate.NewCertificateGetParams()
+			getParams.ID = certID
+			resp, err := svc.Certificate.CertificateGet(getParams)
+			if err != nil {
+				return resource.NonRetryableError(
+					fmt.Errorf("error while retrieving certificate details: %w", err))
+			}
+
+			statusParams := certificate.NewCertificateGetCertificateStatusParams()
+			statusParams.ID = certID
+			statusResp, err := svc.Certificate.CertificateGetCertificateStatus(statusParams)
+			if err != nil {
+				return resource.NonRetryableError(
+					fmt.Errorf("error while retrieving certificate status: %w", err))
+			}
+
+			metadata := GetDomainMetadata(resp, svc)
+			retrySet := CheckForRetry(metadata, statusResp)
+
+			for _, domain := range domains {
+				if stillPending, ok := retrySet[domain]; ok && !stillPending {
+					pending[domain] = false
+				}
+			}
+
+			// Compute statuses (including any per-domain error_message)
+			// before checking for a terminal failure below, so a failed or
+			// cancelled certificate still leaves an informative
+			// domain_status behind instead of the stale pre-failure state.
+			statusPending := pending
+			if s := strings.ToLower(statusResp.Status); s == "failed" || s == "cancelled" {
+				statusPending = nil
+			}
+			statuses = domainStatusesFromMetadata(metadata, statusResp, statusPending, previousValidatedAt)
+			for _, s := range statuses {
+				if validatedAt, _ := s["validated_at"].(string); len(validatedAt) > 0 {
+					previousValidatedAt[s["domain"].(string)] = validatedAt
+				}
+			}
+
+			if status := strings.ToLower(statusResp.Status); status == "failed" || status == "cancelled" {
+				return resource.NonRetryableError(fmt.Errorf(
+					"certificate %d DCV ended in state %q", certID, statusResp.Status))
+			}
+
+			validatedCount := 0
+			for _, domain := range domains {
+				if !pending[domain] {
+					validatedCount++
+				}
+			}
+
+			if partialSuccess && validatedCount > 0 {
+				log.Printf(
+					"[INFO] %d/%d domains validated, partial_success is set - stopping",
+					validatedCount,
+					len(domains))
+				return nil
+			}
+
+			if !anyDomainPending(pending) {
+				return nil
+			}
+
+			log.Printf(
+				"[INFO] %d/%d domains still pending validation, retrying",
+				len(domains)-validatedCount,
+				len(domains))
+			return resource.RetryableError(errors.New("one or more domains still pending DCV"))
+		})
+
+	// Always record whatever status we have, even on error, so a failed or
+	// partially-successful apply still leaves useful state behind.
+	d.Set("domain_status", statuses)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.Diagnostics{}
+}
+
+func ResourceCertificateRead(
+	ctx context.Context,
+	d *schema.ResourceData,
+	m interface{},
+) diag.Diagnostics {
+	config, ok := m.(internal.ProviderConfig)
+	if !ok {
+		return diag.Errorf("failed to load configuration")
+	}
+
+	svc, err := buildCPSService(config)
+	if err != nil {
+		return diag.Errorf("failed to build CPS Service: %v", err)
+	}
+
+	certID, err := helper.ParseInt64(d.Id())
+	if err != nil {
+		d.SetId("")
+		return diag.Errorf("failed to parse certificate ID: %v", err)
+	}
+
+	params := certificate.NewCertificateGetParams()
+	params.ID = certID
+	resp, err := svc.Certificate.CertificateGet(params)
+	if err != nil {
+		d.SetId("")
+		return diag.FromErr(err)
+	}
+
+	statusParams := certificate.NewCertificateGetCertificateStatusParams()
+	statusParams.ID = certID
+	statusResp, err := svc.Certificate.CertificateGetCertificateStatus(statusParams)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	metadata := GetDomainMetadata(resp, svc)
+	d.Set("domain_status", domainStatusesFromMetadata(
+		metadata, statusResp, nil, previousValidatedAtByDomain(d)))
+
+	return diag.Diagnostics{}
+}
+
+func ResourceCertificateDelete(
+	ctx context.Context,
+	d *schema.ResourceData,
+	m interface{},
+) diag.Diagnostics {
+	config, ok := m.(internal.ProviderConfig)
+	if !ok {
+		return diag.Errorf("failed to load configuration")
+	}
+
+	svc, err := buildCPSService(config)
+	if err != nil {
+		return diag.Errorf("failed to build CPS Service: %v", err)
+	}
+
+	certID, err := helper.ParseInt64(d.Id())
+	if err != nil {
+		return diag.Errorf("failed to parse certificate ID: %v", err)
+	}
+
+	deleteParams := certificate.NewCertificateDeleteParams()
+	deleteParams.ID = certID
+	if _, err := svc.Certificate.CertificateDelete(deleteParams); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return diag.Diagnostics{}
+}
+
+// previousValidatedAtByDomain reads the domain_status this resource/data
+// source already has in state, keyed by domain, so domainStatusesFromMetadata
+// can preserve the real one-time validation timestamp instead of restamping
+// it to "now" on every Read.
+func previousValidatedAtByDomain(d *schema.ResourceData) map[string]string {
+	previous := make(map[string]string)
+
+	for _, raw := range d.Get("domain_status").([]interface{}) {
+		entry, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		domain, _ := entry["domain"].(string)
+		validatedAt, _ := entry["validated_at"].(string)
+		if len(domain) > 0 && len(validatedAt) > 0 {
+			previous[domain] = validatedAt
+		}
+	}
+
+	return previous
+}
+
+func domainStatusesFromMetadata(
+	metadata []*models.DomainDcvFull,
+	statusResp *certificate.CertificateGetCertificateStatusOK,
+	pending map[string]bool,
+	previousValidatedAt map[string]string,
+) []map[string]interface{} {
+	statuses := make([]map[string]interface{}, 0, len(metadata))
+
+	for _, domainMetadata := range metadata {
+		status := "pending"
+		errorMessage := ""
+		validatedAt := previousValidatedAt[domainMetadata.Domain]
+
+		if pending == nil || !pending[domainMetadata.Domain] {
+			status = strings.ToLower(statusResp.Status)
+			if domainMetadata.DcvToken != nil && len(domainMetadata.DcvToken.Token) > 0 {
+				status = "validated"
+				if len(validatedAt) == 0 {
+					validatedAt = time.Now().UTC().Format(time.RFC3339)
+				}
+			} else if status == "failed" || status == "cancelled" {
+				errorMessage = fmt.Sprintf(
+					"certificate DCV ended in state %q before this domain validated",
+					statusResp.Status)
+			}
+		}
+
+		token := ""
+		if domainMetadata.DcvToken != nil {
+			token = domainMetadata.DcvToken.Token
+		}
+
+		statuses = append(statuses, map[string]interface{}{
+			"domain":        domainMetadata.Domain,
+			"token":         token,
+			"status":        status,
+			"validated_at":  validatedAt,
+			"error_message": errorMessage,
+		})
+	}
+
+	return statuses
+}
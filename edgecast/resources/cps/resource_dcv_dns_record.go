@@ -0,0 +1,221 @@
+// Copyright 2022 Edgecast Inc., Licensed under the terms of the Apache 2.0 license.
+// See LICENSE file in project root for terms.
+package cps
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"terraform-provider-edgecast/edgecast/helper"
+	"terraform-provider-edgecast/edgecast/internal"
+	"time"
+
+	"github.com/EdgeCast/ec-sdk-go/edgecast/cps/certificate"
+	"github.com/EdgeCast/ec-sdk-go/edgecast/cps/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const dcvDNSRecordDefaultTimeout = "20m"
+
+// ResourceDCVDNSRecord publishes the `_dcv-*` TXT record CPS requires for
+// DNS-based domain control validation, so end-to-end DV issuance can happen
+// in a single `terraform apply` instead of an operator wiring
+// DataSourceDNSTXTToken's output into DNS by hand.
+func ResourceDCVDNSRecord() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: ResourceDCVDNSRecordCreate,
+		ReadContext:   ResourceDCVDNSRecordRead,
+		DeleteContext: ResourceDCVDNSRecordDelete,
+		Schema: map[string]*schema.Schema{
+			"certificate_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"dns_provider": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem:     dnsProviderSchema(),
+			},
+			"wait_timeout": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          dcvDNSRecordDefaultTimeout,
+				ValidateDiagFunc: internal.ValidateDuration,
+			},
+			"cleanup_on_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether to remove the TXT record when this resource is destroyed.",
+			},
+			"domain": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"record_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"record_value": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func ResourceDCVDNSRecordCreate(
+	ctx context.Context,
+	d *schema.ResourceData,
+	m interface{},
+) diag.Diagnostics {
+	timeoutRaw := d.Get("wait_timeout").(string)
+	timeout, err := time.ParseDuration(timeoutRaw)
+	if err != nil {
+		return diag.Errorf("invalid wait_timeout: %v", err)
+	}
+
+	config, ok := m.(internal.ProviderConfig)
+	if !ok {
+		return diag.Errorf("failed to load configuration")
+	}
+
+	svc, err := buildCPSService(config)
+	if err != nil {
+		return diag.Errorf("failed to build CPS Service: %v", err)
+	}
+
+	certID, err := helper.ParseInt64(d.Get("certificate_id").(string))
+	if err != nil {
+		return diag.Errorf("failed to parse certificate ID: %v", err)
+	}
+
+	dnsProvider, err := buildDCVDNSProvider(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	params := certificate.NewCertificateGetParams()
+	params.ID = certID
+	statusParams := certificate.NewCertificateGetCertificateStatusParams()
+	statusParams.ID = certID
+
+	var recordName, recordValue, domain string
+	var published bool
+
+	err = resource.RetryContext(
+		ctx,
+		timeout,
+		func() *resource.RetryError {
+			resp, err := svc.Certificate.CertificateGet(params)
+			if err != nil {
+				return resource.NonRetryableError(
+					fmt.Errorf("error while retrieving certificate details: %w", err))
+			}
+
+			statusResp, err := svc.Certificate.CertificateGetCertificateStatus(statusParams)
+			if err != nil {
+				return resource.NonRetryableError(
+					fmt.Errorf("error while retrieving certificate status: %w", err))
+			}
+
+			if resp.ValidationType != models.CdnProvidedCertificateValidationTypeDV {
+				return resource.NonRetryableError(
+					errors.New("certificate must have validation type DV"))
+			}
+
+			if len(resp.WorkflowErrorMessage) > 0 {
+				return resource.NonRetryableError(
+					fmt.Errorf("error in workflow: %s", resp.WorkflowErrorMessage))
+			}
+
+			metadata := GetDomainMetadata(resp, svc)
+			if anyDomainPending(CheckForRetry(metadata, statusResp)) {
+				log.Println("[INFO] DCV token not yet available, retrying")
+				return resource.RetryableError(errors.New("token not available"))
+			}
+
+			if !published {
+				domain = metadata[0].Domain
+				recordValue = metadata[0].DcvToken.Token
+				recordName = dcvRecordName(domain)
+
+				log.Printf("[INFO] publishing TXT record %s", recordName)
+				if err := dnsProvider.PublishTXTRecord(ctx, recordName, recordValue); err != nil {
+					return resource.NonRetryableError(err)
+				}
+				published = true
+
+				// CPS needs a moment to observe the published record before
+				// it will validate, so fall through and retry the status
+				// check rather than treating publication as completion.
+				return resource.RetryableError(
+					errors.New("TXT record published, waiting for validation"))
+			}
+
+			switch strings.ToLower(statusResp.Status) {
+			case "processing":
+				return resource.RetryableError(errors.New("certificate still processing"))
+			case "failed", "cancelled":
+				return resource.NonRetryableError(fmt.Errorf(
+					"certificate %d DCV ended in state %q", certID, statusResp.Status))
+			default:
+				return nil
+			}
+		})
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", d.Get("certificate_id").(string), recordName))
+	d.Set("domain", domain)
+	d.Set("record_name", recordName)
+	d.Set("record_value", recordValue)
+
+	return diag.Diagnostics{}
+}
+
+func ResourceDCVDNSRecordRead(
+	ctx context.Context,
+	d *schema.ResourceData,
+	m interface{},
+) diag.Diagnostics {
+	// The TXT record's contents are derived entirely from the certificate's
+	// DCV token, which does not change for the life of the certificate, so
+	// there is nothing further to refresh here.
+	return diag.Diagnostics{}
+}
+
+func ResourceDCVDNSRecordDelete(
+	ctx context.Context,
+	d *schema.ResourceData,
+	m interface{},
+) diag.Diagnostics {
+	if !d.Get("cleanup_on_destroy").(bool) {
+		return diag.Diagnostics{}
+	}
+
+	dnsProvider, err := buildDCVDNSProvider(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	recordName := d.Get("record_name").(string)
+	if err := dnsProvider.DeleteTXTRecord(ctx, recordName); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.Diagnostics{}
+}
+
+func dcvRecordName(domain string) string {
+	return fmt.Sprintf("_dcv.%s", domain)
+}